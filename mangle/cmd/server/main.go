@@ -4,55 +4,334 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
-	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
+	gwruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 
+	"github.com/yaboyshades/vdw-orchestrator/mangle/datalog"
 	pb "github.com/yaboyshades/vdw-orchestrator/mangle/generated/reasoning"
+	apphealth "github.com/yaboyshades/vdw-orchestrator/mangle/health"
 )
 
 type reasoningServer struct {
 	pb.UnimplementedReasoningServiceServer
 	rulesFile string
-	rules     []string
+
+	mu              sync.RWMutex
+	engine          *datalog.Engine
+	lastResult      datalog.Result // provenance behind the most recent ApplyRules call, for ExplainConclusion
+	lastEvalLatency time.Duration
+
+	conclusions *conclusionHub // fed by every StreamApplyRules session, consulted by SubscribeConclusions
 }
 
-// ApplyRules implements the reasoning logic
+// ApplyRules ingests req.Facts as EDB tuples, evaluates the currently
+// loaded program to a fixpoint, and returns the newly derived conclusions
+// together with the names of the rules that fired. When req.Explain is
+// set, the response also carries the proof tree(s) behind each
+// conclusion, and the evaluation's provenance is retained for later
+// ExplainConclusion calls.
 func (s *reasoningServer) ApplyRules(ctx context.Context, req *pb.ReasoningRequest) (*pb.ReasoningResponse, error) {
 	log.Printf("Received reasoning request with %d facts", len(req.Facts))
-	
-	// Simple mock reasoning - in a real implementation, this would use
-	// a proper reasoning engine like Datalog, Prolog, or custom logic
-	conclusions := make([]string, 0)
-	appliedRules := make([]string, 0)
-	
-	// Example reasoning: if we have facts about being human and mortal
-	for _, fact := range req.Facts {
-		if strings.Contains(fact, "human") {
-			conclusions = append(conclusions, "mortal")
-			appliedRules = append(appliedRules, "human -> mortal")
+
+	s.mu.RLock()
+	engine := s.engine
+	s.mu.RUnlock()
+	if engine == nil {
+		return &pb.ReasoningResponse{
+			Success:      false,
+			ErrorMessage: "no rules loaded: call LoadRules first",
+		}, nil
+	}
+
+	facts, err := parseFacts(req.Facts)
+	if err != nil {
+		return &pb.ReasoningResponse{Success: false, ErrorMessage: err.Error()}, nil
+	}
+
+	start := time.Now()
+	result := engine.Evaluate(facts)
+	s.mu.Lock()
+	s.lastEvalLatency = time.Since(start)
+	s.mu.Unlock()
+
+	conclusions := make([]string, len(result.Conclusions))
+	for i, a := range result.Conclusions {
+		conclusions[i] = a.String()
+	}
+
+	resp := &pb.ReasoningResponse{
+		Conclusions:  conclusions,
+		AppliedRules: result.AppliedRules,
+		Success:      true,
+		ErrorMessage: "",
+	}
+
+	if req.Explain {
+		resp.Justifications = make(map[string]*pb.JustificationList, len(result.Conclusions))
+		for _, a := range result.Conclusions {
+			proofs, ok := result.Explain(a, int(req.MaxProofs))
+			if !ok {
+				continue
+			}
+			pbProofs := make([]*pb.Justification, len(proofs))
+			for i, j := range proofs {
+				pbProofs[i] = toPBJustification(j)
+			}
+			resp.Justifications[a.String()] = &pb.JustificationList{Proofs: pbProofs}
 		}
-		if strings.Contains(fact, "bird") && strings.Contains(fact, "can_fly") {
-			conclusions = append(conclusions, "aerial_creature")
-			appliedRules = append(appliedRules, "bird âˆ§ can_fly -> aerial_creature")
+		s.mu.Lock()
+		s.lastResult = result
+		s.mu.Unlock()
+	}
+
+	return resp, nil
+}
+
+// ExplainConclusion looks up the proof tree(s) behind a conclusion
+// returned by the most recent explain-enabled ApplyRules call.
+func (s *reasoningServer) ExplainConclusion(ctx context.Context, req *pb.ExplainConclusionRequest) (*pb.ExplainConclusionResponse, error) {
+	fact, err := datalog.ParseFact(req.Conclusion)
+	if err != nil {
+		return &pb.ExplainConclusionResponse{
+			Found:        false,
+			ErrorMessage: fmt.Sprintf("invalid conclusion %q: %v", req.Conclusion, err),
+		}, nil
+	}
+
+	s.mu.RLock()
+	result := s.lastResult
+	s.mu.RUnlock()
+
+	proofs, ok := result.Explain(fact, int(req.MaxProofs))
+	if !ok {
+		return &pb.ExplainConclusionResponse{Found: false}, nil
+	}
+	pbProofs := make([]*pb.Justification, len(proofs))
+	for i, j := range proofs {
+		pbProofs[i] = toPBJustification(j)
+	}
+	return &pb.ExplainConclusionResponse{Found: true, Proofs: pbProofs}, nil
+}
+
+func toPBJustification(j *datalog.Justification) *pb.Justification {
+	premises := make([]*pb.Justification, len(j.Premises))
+	for i, p := range j.Premises {
+		premises[i] = toPBJustification(p)
+	}
+	return &pb.Justification{
+		Fact:     j.Fact.String(),
+		Rule:     j.Rule,
+		Bindings: j.Bindings,
+		Premises: premises,
+	}
+}
+
+// parseFacts parses each of raw as a ground Datalog fact, failing on the
+// first one that doesn't parse.
+func parseFacts(raw []string) ([]datalog.Atom, error) {
+	facts := make([]datalog.Atom, 0, len(raw))
+	for _, r := range raw {
+		atom, err := datalog.ParseFact(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fact %q: %v", r, err)
+		}
+		facts = append(facts, atom)
+	}
+	return facts, nil
+}
+
+// maxFactsPerStream bounds how many EDB facts a single StreamApplyRules
+// session may accumulate over its lifetime. It exists so that one
+// long-lived or misbehaving client can't grow its session's memory
+// footprint without bound; the stream is closed with ResourceExhausted
+// once it's exceeded.
+const maxFactsPerStream = 50000
+
+// StreamApplyRules ingests facts (and retractions) incrementally: each
+// request is evaluated against a session-local EDB/IDB that persists for
+// the lifetime of the stream rather than the whole server's engine, and
+// the response for that request carries only the conclusions that
+// became newly true or newly false as a result of it. The session, and
+// everything derived in it, is discarded when the stream ends; Recv
+// returning an error (including the client disconnecting, which cancels
+// stream.Context()) ends the loop and releases it.
+func (s *reasoningServer) StreamApplyRules(stream pb.ReasoningService_StreamApplyRulesServer) error {
+	s.mu.RLock()
+	engine := s.engine
+	s.mu.RUnlock()
+	if engine == nil {
+		return status.Error(codes.FailedPrecondition, "no rules loaded: call LoadRules first")
+	}
+
+	ctx := stream.Context()
+	session := engine.NewState()
+	factCount := 0
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil
+		}
+
+		resp := &pb.ReasoningResponse{Success: true}
+
+		if len(req.Retract) > 0 {
+			retractFacts, perr := parseFacts(req.Retract)
+			if perr != nil {
+				if err := stream.Send(&pb.ReasoningResponse{Success: false, ErrorMessage: perr.Error()}); err != nil {
+					return err
+				}
+				continue
+			}
+			result := session.Retract(retractFacts)
+			for _, a := range result.Conclusions {
+				resp.RetractedConclusions = append(resp.RetractedConclusions, a.String())
+				s.conclusions.publish(a.String(), true)
+			}
+		}
+
+		if len(req.Facts) > 0 {
+			factCount += len(req.Facts)
+			if factCount > maxFactsPerStream {
+				return status.Errorf(codes.ResourceExhausted, "stream exceeded the %d-fact session limit", maxFactsPerStream)
+			}
+			facts, perr := parseFacts(req.Facts)
+			if perr != nil {
+				if err := stream.Send(&pb.ReasoningResponse{Success: false, ErrorMessage: perr.Error()}); err != nil {
+					return err
+				}
+				continue
+			}
+			result := session.Add(facts)
+			resp.AppliedRules = result.AppliedRules
+			for _, a := range result.Conclusions {
+				resp.Conclusions = append(resp.Conclusions, a.String())
+				s.conclusions.publish(a.String(), false)
+			}
+			// Ordinarily empty: only populated when one of the facts just
+			// added feeds a predicate some rule negates, which forces Add
+			// to fall back to full re-derivation (see State.Add) and can
+			// retract conclusions that depended on that predicate's prior
+			// absence.
+			for _, a := range result.Retracted {
+				resp.RetractedConclusions = append(resp.RetractedConclusions, a.String())
+				s.conclusions.publish(a.String(), true)
+			}
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// SubscribeConclusions streams every conclusion matching req.Predicate
+// (and, if req.Filter is set, containing it as a substring of the
+// conclusion's string form) derived or retracted by any StreamApplyRules
+// session on this server, until the client disconnects.
+func (s *reasoningServer) SubscribeConclusions(req *pb.SubscribeConclusionsRequest, stream pb.ReasoningService_SubscribeConclusionsServer) error {
+	ch, unsubscribe := s.conclusions.subscribe()
+	defer unsubscribe()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev := <-ch:
+			if !matchesSubscription(ev.conclusion, req.Predicate, req.Filter) {
+				continue
+			}
+			if err := stream.Send(&pb.ConclusionEvent{Conclusion: ev.conclusion, Retracted: ev.retracted}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func matchesSubscription(conclusion, predicate, filter string) bool {
+	if predicate != "" && !strings.HasPrefix(conclusion, predicate+"(") {
+		return false
+	}
+	if filter != "" && !strings.Contains(conclusion, filter) {
+		return false
+	}
+	return true
+}
+
+// conclusionEvent is one tuple becoming newly true or newly false in a
+// StreamApplyRules session, fanned out to every SubscribeConclusions
+// call by a conclusionHub.
+type conclusionEvent struct {
+	conclusion string
+	retracted  bool
+}
+
+// conclusionHub fans out conclusionEvents from every StreamApplyRules
+// session to every active SubscribeConclusions call. publish never
+// blocks: a subscriber whose channel is full simply misses events rather
+// than stalling a reasoning session on a slow watcher.
+type conclusionHub struct {
+	mu   sync.Mutex
+	next int
+	subs map[int]chan conclusionEvent
+}
+
+func newConclusionHub() *conclusionHub {
+	return &conclusionHub{subs: make(map[int]chan conclusionEvent)}
+}
+
+// subscribe registers a new listener and returns its channel along with
+// a function that unregisters it; callers must defer the unsubscribe
+// function to avoid leaking the channel.
+func (h *conclusionHub) subscribe() (<-chan conclusionEvent, func()) {
+	h.mu.Lock()
+	id := h.next
+	h.next++
+	ch := make(chan conclusionEvent, 64)
+	h.subs[id] = ch
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.subs, id)
+		h.mu.Unlock()
+	}
+}
+
+func (h *conclusionHub) publish(conclusion string, retracted bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, ch := range h.subs {
+		select {
+		case ch <- conclusionEvent{conclusion: conclusion, retracted: retracted}:
+		default:
 		}
 	}
-	
-	return &pb.ReasoningResponse{
-		Conclusions:   conclusions,
-		AppliedRules:  appliedRules,
-		Success:       true,
-		ErrorMessage:  "",
-	}, nil
 }
 
 // HealthCheck implements health checking
@@ -63,91 +342,254 @@ func (s *reasoningServer) HealthCheck(ctx context.Context, req *pb.HealthCheckRe
 	}, nil
 }
 
-// LoadRules loads new reasoning rules
+// LoadRules parses req.RulesContent as a Datalog program, stratifies it,
+// and — only if both steps succeed — replaces the engine the server
+// evaluates ApplyRules requests against.
 func (s *reasoningServer) LoadRules(ctx context.Context, req *pb.LoadRulesRequest) (*pb.LoadRulesResponse, error) {
-	// Parse rules content
-	rules := strings.Split(req.RulesContent, "\n")
-	validRules := make([]string, 0)
-	
-	for _, rule := range rules {
-		rule = strings.TrimSpace(rule)
-		if rule != "" && !strings.HasPrefix(rule, "//") {
-			validRules = append(validRules, rule)
-		}
+	prog, err := datalog.Parse(req.RulesContent)
+	if err != nil {
+		return &pb.LoadRulesResponse{
+			Success:      false,
+			ErrorMessage: err.Error(),
+		}, nil
 	}
-	
-	s.rules = validRules
-	
+
+	engine, err := datalog.NewEngine(prog)
+	if err != nil {
+		return &pb.LoadRulesResponse{
+			Success:      false,
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	s.mu.Lock()
+	s.engine = engine
+	s.mu.Unlock()
+
 	return &pb.LoadRulesResponse{
 		Success:      true,
 		ErrorMessage: "",
-		RulesLoaded:  int32(len(validRules)),
+		RulesLoaded:  int32(len(prog.IDBRules())),
 	}, nil
 }
 
+// checkDatalogLoaded reports whether a rules program has been loaded.
+func (s *reasoningServer) checkDatalogLoaded(ctx context.Context) (apphealth.Status, string, error) {
+	s.mu.RLock()
+	loaded := s.engine != nil
+	s.mu.RUnlock()
+	if !loaded {
+		return apphealth.StatusNotServing, "no rules loaded", nil
+	}
+	return apphealth.StatusServing, "rules loaded", nil
+}
+
+// checkRulesFileReadable reports whether the configured rules file still
+// exists and is readable, independent of whether it has been (re)loaded.
+func (s *reasoningServer) checkRulesFileReadable(ctx context.Context) (apphealth.Status, string, error) {
+	f, err := os.Open(s.rulesFile)
+	if err != nil {
+		return apphealth.StatusNotServing, err.Error(), nil
+	}
+	f.Close()
+	return apphealth.StatusServing, s.rulesFile + " is readable", nil
+}
+
+// checkEvalLatency returns a Checker func reporting whether the most
+// recent ApplyRules evaluation completed within ceiling.
+func (s *reasoningServer) checkEvalLatency(ceiling time.Duration) func(context.Context) (apphealth.Status, string, error) {
+	return func(ctx context.Context) (apphealth.Status, string, error) {
+		s.mu.RLock()
+		latency := s.lastEvalLatency
+		s.mu.RUnlock()
+		detail := fmt.Sprintf("last evaluation took %s (ceiling %s)", latency, ceiling)
+		if latency > ceiling {
+			return apphealth.StatusNotServing, detail, nil
+		}
+		return apphealth.StatusServing, detail, nil
+	}
+}
+
+// checkMemoryCeiling returns a Checker func reporting whether current
+// heap allocation is within ceilingMB mebibytes.
+func checkMemoryCeiling(ceilingMB uint64) func(context.Context) (apphealth.Status, string, error) {
+	return func(ctx context.Context) (apphealth.Status, string, error) {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		allocMB := stats.Alloc / (1024 * 1024)
+		detail := fmt.Sprintf("heap allocation is %d MiB (ceiling %d MiB)", allocMB, ceilingMB)
+		if allocMB > ceilingMB {
+			return apphealth.StatusNotServing, detail, nil
+		}
+		return apphealth.StatusServing, detail, nil
+	}
+}
+
+// runHealthCheckClient dials the server's own health endpoint on port and
+// returns 0 if every monitored subsystem (plus the overall "" status) is
+// SERVING, or 1 otherwise — suitable for a Kubernetes liveness/readiness
+// probe or a `docker HEALTHCHECK`.
+func runHealthCheckClient(port string) int {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, "localhost:"+port,
+		grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	if err != nil {
+		fmt.Printf("health check failed: could not connect: %v\n", err)
+		return 1
+	}
+	defer conn.Close()
+
+	client := grpc_health_v1.NewHealthClient(conn)
+	healthy := true
+	for _, service := range append([]string{""}, monitoredServices...) {
+		resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+		if err != nil {
+			fmt.Printf("health check failed: %s: %v\n", serviceLabel(service), err)
+			healthy = false
+			continue
+		}
+		if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+			fmt.Printf("health check failed: %s is %s\n", serviceLabel(service), resp.Status)
+			healthy = false
+		}
+	}
+
+	if !healthy {
+		return 1
+	}
+	fmt.Println("Health check passed")
+	return 0
+}
+
+func serviceLabel(service string) string {
+	if service == "" {
+		return "overall status"
+	}
+	return service
+}
+
+// monitoredServices are the subsystem names registered with the health
+// Monitor; --health-check dials the server and fails if any of them
+// (besides the overall "" status) is reported NOT_SERVING.
+var monitoredServices = []string{"datalog", "rules-file", "eval-latency", "memory", "recent-failures"}
+
 func main() {
 	var (
-		port      = flag.String("port", "50051", "The server port")
-		rulesFile = flag.String("rules", "reasoning_rules.dl", "Path to reasoning rules file")
-		healthCheck = flag.Bool("health-check", false, "Perform health check and exit")
+		port               = flag.String("port", "50051", "The server port")
+		httpPort           = flag.String("http-port", "8080", "The HTTP+JSON gateway port")
+		rulesFile          = flag.String("rules", "reasoning_rules.dl", "Path to reasoning rules file")
+		healthCheck        = flag.Bool("health-check", false, "Dial the server's own health endpoint and exit non-zero if any subsystem is unhealthy")
+		healthInterval     = flag.Duration("health-check-interval", 10*time.Second, "How often background health checks run")
+		evalLatencyCeiling = flag.Duration("eval-latency-ceiling", 500*time.Millisecond, "Maximum acceptable ApplyRules evaluation latency")
+		memoryCeilingMB    = flag.Uint64("memory-ceiling-mb", 512, "Maximum acceptable heap allocation, in MiB")
 	)
 	flag.Parse()
-	
+
 	if *healthCheck {
-		// Simple health check - just verify the server can start
-		lis, err := net.Listen("tcp", ":"+*port)
-		if err != nil {
-			log.Fatalf("Health check failed: %v", err)
-		}
-		lis.Close()
-		fmt.Println("Health check passed")
-		os.Exit(0)
+		os.Exit(runHealthCheckClient(*port))
 	}
-	
+
 	lis, err := net.Listen("tcp", ":"+*port)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
-	
-	// Create gRPC server
-	s := grpc.NewServer()
-	
+
 	// Create reasoning server instance
 	reasoningServer := &reasoningServer{
-		rulesFile: *rulesFile,
-		rules:     make([]string, 0),
+		rulesFile:   *rulesFile,
+		conclusions: newConclusionHub(),
 	}
-	
+
+	// Create gRPC server with an interceptor that turns panics and bare
+	// errors into structured gRPC status codes and feeds a recent-failure
+	// counter consulted by the health Monitor below.
+	failureTracker := apphealth.NewFailureTracker(time.Minute, 5)
+	s := grpc.NewServer(grpc.UnaryInterceptor(apphealth.UnaryServerInterceptor(failureTracker)))
+
 	// Load initial rules if file exists
-	if _, err := os.Stat(*rulesFile); err == nil {
+	if content, err := os.ReadFile(*rulesFile); err == nil {
 		log.Printf("Loading rules from %s", *rulesFile)
-		// In a real implementation, you would parse the Datalog file
-		// For now, just log that we found the file
+		resp, err := reasoningServer.LoadRules(context.Background(), &pb.LoadRulesRequest{RulesContent: string(content)})
+		if err != nil {
+			log.Fatalf("Failed to load rules from %s: %v", *rulesFile, err)
+		}
+		if !resp.Success {
+			log.Fatalf("Failed to load rules from %s: %s", *rulesFile, resp.ErrorMessage)
+		}
+		log.Printf("Loaded %d rule(s) from %s", resp.RulesLoaded, *rulesFile)
 	}
 	
 	// Register services
 	pb.RegisterReasoningServiceServer(s, reasoningServer)
 	
 	// Register health service
-	healthServer := health.NewServer()
+	healthServer := grpchealth.NewServer()
 	grpc_health_v1.RegisterHealthServer(s, healthServer)
 	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
 	
 	// Register reflection service (useful for debugging)
 	reflection.Register(s)
-	
-	log.Printf("Starting mangle reasoning server on port %s", *port)
+
+	// Register per-subsystem health checkers and start the background
+	// monitor that keeps their serving statuses up to date, so a client
+	// can Watch("datalog") and see transitions independently of the
+	// overall "" status.
+	monitor := apphealth.NewMonitor(healthServer, *healthInterval,
+		apphealth.NewCheckFunc("datalog", reasoningServer.checkDatalogLoaded),
+		apphealth.NewCheckFunc("rules-file", reasoningServer.checkRulesFileReadable),
+		apphealth.NewCheckFunc("eval-latency", reasoningServer.checkEvalLatency(*evalLatencyCeiling)),
+		apphealth.NewCheckFunc("memory", checkMemoryCeiling(*memoryCeilingMB)),
+		failureTracker,
+	)
+	monitorCtx, stopMonitor := context.WithCancel(context.Background())
+	defer stopMonitor()
+	go monitor.Run(monitorCtx)
+
+	// Set up the grpc-gateway so ApplyRules, LoadRules and HealthCheck are
+	// also reachable as HTTP+JSON, sharing the same reasoningServer
+	// instance via an in-process gRPC connection to our own listener.
+	gwMux := gwruntime.NewServeMux()
+	conn, err := grpc.DialContext(context.Background(), "localhost:"+*port, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to dial gRPC server for gateway: %v", err)
+	}
+	if err := pb.RegisterReasoningServiceHandler(context.Background(), gwMux, conn); err != nil {
+		log.Fatalf("Failed to register gateway handler: %v", err)
+	}
+
+	httpMux := http.NewServeMux()
+	httpMux.Handle("/v1/", gwMux)
+	httpMux.HandleFunc("/swagger.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(pb.SwaggerJSON)
+	})
+	httpServer := &http.Server{Addr: ":" + *httpPort, Handler: httpMux}
+
+	log.Printf("Starting mangle reasoning server: gRPC on port %s, HTTP+JSON gateway on port %s", *port, *httpPort)
 	log.Printf("Rules file: %s", *rulesFile)
-	
-	// Handle graceful shutdown
+
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to serve HTTP gateway: %v", err)
+		}
+	}()
+
+	// Handle graceful shutdown of both listeners
 	go func() {
 		c := make(chan os.Signal, 1)
 		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		<-c
 		log.Println("Shutting down server...")
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(ctx); err != nil {
+			log.Printf("HTTP gateway shutdown error: %v", err)
+		}
 		s.GracefulStop()
 	}()
-	
+
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
@@ -0,0 +1,107 @@
+package datalog
+
+import "strings"
+
+// Term is a single argument of an atom: either a variable (capitalized, to
+// be bound during evaluation) or a constant (everything else).
+type Term struct {
+	Value    string
+	Variable bool
+}
+
+// IsVariable reports whether t ranges over bindings rather than naming a
+// constant.
+func (t Term) IsVariable() bool { return t.Variable }
+
+func (t Term) String() string { return t.Value }
+
+// Atom is a predicate applied to a fixed-arity list of terms, e.g.
+// edge(X, Y) or edge(a, b).
+type Atom struct {
+	Predicate string
+	Args      []Term
+}
+
+// Arity returns the number of arguments the atom is applied to.
+func (a Atom) Arity() int { return len(a.Args) }
+
+// Ground reports whether every argument of the atom is a constant.
+func (a Atom) Ground() bool {
+	for _, arg := range a.Args {
+		if arg.Variable {
+			return false
+		}
+	}
+	return true
+}
+
+func (a Atom) String() string {
+	args := make([]string, len(a.Args))
+	for i, arg := range a.Args {
+		args[i] = arg.Value
+	}
+	return a.Predicate + "(" + strings.Join(args, ", ") + ")"
+}
+
+// Literal is a body element: an atom, optionally negated.
+type Literal struct {
+	Atom     Atom
+	Negated  bool
+}
+
+func (l Literal) String() string {
+	if l.Negated {
+		return "not " + l.Atom.String()
+	}
+	return l.Atom.String()
+}
+
+// Rule is a safe, range-restricted Horn clause: every variable in Head must
+// appear in a positive body literal. A Rule with an empty Body is a fact.
+type Rule struct {
+	Head Atom
+	Body []Literal
+}
+
+// IsFact reports whether the rule is a bodyless ground fact.
+func (r Rule) IsFact() bool { return len(r.Body) == 0 }
+
+func (r Rule) String() string {
+	if r.IsFact() {
+		return r.Head.String() + "."
+	}
+	lits := make([]string, len(r.Body))
+	for i, l := range r.Body {
+		lits[i] = l.String()
+	}
+	return r.Head.String() + " :- " + strings.Join(lits, ", ") + "."
+}
+
+// Program is a parsed Datalog source: the union of its bodyless rules forms
+// the base facts (EDB); the rest define the intensional predicates (IDB).
+type Program struct {
+	Rules []Rule
+}
+
+// Facts returns the bodyless rules of the program, i.e. its EDB.
+func (p Program) Facts() []Atom {
+	facts := make([]Atom, 0, len(p.Rules))
+	for _, r := range p.Rules {
+		if r.IsFact() {
+			facts = append(facts, r.Head)
+		}
+	}
+	return facts
+}
+
+// IDBRules returns the rules with a non-empty body, i.e. its derivation
+// rules.
+func (p Program) IDBRules() []Rule {
+	rules := make([]Rule, 0, len(p.Rules))
+	for _, r := range p.Rules {
+		if !r.IsFact() {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
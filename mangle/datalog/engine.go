@@ -0,0 +1,438 @@
+package datalog
+
+// Engine holds a loaded, stratified program and evaluates it against a
+// set of extensional facts.
+type Engine struct {
+	rules [][]Rule // rules[i] is stratum i, in dependency order
+
+	// negatedPredicates is the set of predicates that appear in some
+	// `not p(...)` body literal anywhere in the program. State.Add
+	// consults it to detect assertions that are unsafe for a forward
+	// semi-naive delta pass (see the comment on that check).
+	negatedPredicates map[string]bool
+}
+
+// NewEngine stratifies prog's rules, rejecting programs with negation
+// inside a dependency cycle.
+func NewEngine(prog *Program) (*Engine, error) {
+	rules := prog.IDBRules()
+	strata, err := stratify(rules)
+	if err != nil {
+		return nil, err
+	}
+	negated := map[string]bool{}
+	for _, r := range rules {
+		for _, lit := range r.Body {
+			if lit.Negated {
+				negated[lit.Atom.Predicate] = true
+			}
+		}
+	}
+	return &Engine{rules: strata, negatedPredicates: negated}, nil
+}
+
+// binding maps variable names to the constants they are bound to while
+// evaluating a single rule instance.
+type binding map[string]string
+
+func (b binding) resolve(t Term) (string, bool) {
+	if !t.Variable {
+		return t.Value, true
+	}
+	v, ok := b[t.Value]
+	return v, ok
+}
+
+// groundAtom applies a binding to every argument of an atom, returning the
+// fully ground atom (all variables in a safe rule are bound by this point).
+func groundAtom(a Atom, b binding) Atom {
+	args := make([]Term, len(a.Args))
+	for i, arg := range a.Args {
+		if arg.Variable {
+			args[i] = Term{Value: b[arg.Value]}
+		} else {
+			args[i] = arg
+		}
+	}
+	return Atom{Predicate: a.Predicate, Args: args}
+}
+
+// relation is a set of ground atoms for a single predicate, keyed by their
+// string form for O(1) membership tests.
+type relation map[string]Atom
+
+func relKey(a Atom) string { return a.String() }
+
+// derivation records one rule instance that produced a tuple — the rule,
+// the variable bindings it fired with, and the body tuples (premises) that
+// justified it — for explanation support. A tuple may have more than one
+// derivation if it can be reached via more than one rule instance.
+type derivation struct {
+	rule     *Rule
+	bindings binding
+	premises []Atom
+}
+
+// maxDerivationsPerTuple bounds how many alternate derivations Evaluate
+// keeps per tuple; beyond this, later derivations of an already-derived
+// tuple are not recorded. This keeps provenance tracking proportional to
+// the EDB/IDB size rather than to the (potentially exponential) number of
+// proofs of a deeply recursive tuple.
+const maxDerivationsPerTuple = 8
+
+// Result is the outcome of running ApplyRules: the full set of conclusions
+// newly derived from the supplied facts, the rules that fired, and the
+// provenance behind each conclusion (consulted by Explain). Retracted is
+// only ever populated by a State.Add call that asserted into a negated
+// predicate and so had to fall back to full re-derivation (see the
+// comment on Add): the tuples it lists were true before the call and are
+// no longer true afterward. State.Retract reports the same kind of
+// tuples, but via Conclusions instead, to match its own documented
+// contract.
+type Result struct {
+	Conclusions  []Atom
+	Retracted    []Atom
+	AppliedRules []string
+	provenance   map[string][]*derivation
+}
+
+// Evaluate runs bottom-up semi-naive evaluation to a fixpoint, seeding the
+// EDB with facts, and returns every newly derived (IDB) tuple along with
+// the rules that produced at least one tuple. It is a one-shot
+// convenience wrapper around NewState().Add(facts) for callers, such as
+// the stateless ApplyRules RPC, that don't need the EDB to persist
+// between calls.
+func (e *Engine) Evaluate(facts []Atom) Result {
+	return e.NewState().Add(facts)
+}
+
+// NewState starts a fresh, empty session against e: an EDB/IDB pair that
+// persists across repeated calls to Add and Retract, for callers such as
+// the StreamApplyRules RPC that ingest facts incrementally.
+func (e *Engine) NewState() *State {
+	return &State{
+		engine:     e,
+		edb:        relation{},
+		all:        relation{},
+		provenance: map[string][]*derivation{},
+	}
+}
+
+// State is one session's persistent EDB/IDB pair. Add and Retract mutate
+// it in place and return only the tuples that changed truth value as a
+// result of that call, not the full IDB every time. A State is not safe
+// for concurrent use; callers that need that (e.g. one per stream) must
+// serialize access themselves.
+type State struct {
+	engine *Engine
+
+	edb        relation // base facts supplied via Add, independent of derivation
+	all        relation // edb ∪ every currently-true derived tuple
+	provenance map[string][]*derivation
+}
+
+func newDerivationRecorder(provenance map[string][]*derivation) func(key string, rule *Rule, b binding) {
+	return func(key string, rule *Rule, b binding) {
+		if len(provenance[key]) >= maxDerivationsPerTuple {
+			return
+		}
+		provenance[key] = append(provenance[key], &derivation{
+			rule:     rule,
+			bindings: b,
+			premises: premisesOf(rule.Body, b),
+		})
+	}
+}
+
+// Add ingests facts as new EDB tuples and returns the tuples (facts and
+// derived conclusions alike) that became true for the first time as a
+// result of this call, not the full accumulated state — plus, in the
+// case described below, any tuples that became false.
+//
+// If every newly added fact is for a predicate that never appears
+// negated anywhere in the program, Add runs cheap forward semi-naive
+// delta evaluation: sound, because nothing already derived can have
+// depended on the *absence* of a newly added tuple. But if a newly
+// added fact IS for a predicate that appears negated somewhere (e.g.
+// asserting r(a) where some rule has `not r(X)` in its body), a
+// forward-only delta pass is unsound — a conclusion derived earlier
+// under r(a)'s prior absence may no longer hold, and semi-naive delta
+// evaluation has no way to retract it. Add detects that case and falls
+// back to the same full re-derivation Retract uses, via fullRecompute, so
+// Result.Retracted comes back populated with whatever lost support.
+func (st *State) Add(facts []Atom) Result {
+	// hadPriorState must be captured before the loop below touches
+	// anything: a fresh session (Evaluate's one-shot NewState().Add, or
+	// the first Add on a new State) has no existing derived tuples that
+	// could depend on a negated predicate's prior absence, so asserting
+	// into one is always safe there. This also keeps fullRecompute below
+	// from recursing: it re-enters via Engine.Evaluate, which always
+	// starts from an empty state.
+	hadPriorState := len(st.all) > 0
+
+	seed := relation{}
+	assertsIntoNegatedPredicate := false
+	for _, f := range facts {
+		key := relKey(f)
+		st.edb[key] = f
+		if _, exists := st.all[key]; !exists {
+			seed[key] = f
+			if st.engine.negatedPredicates[f.Predicate] {
+				assertsIntoNegatedPredicate = true
+			}
+		}
+	}
+
+	if len(seed) == 0 {
+		return Result{provenance: st.provenance}
+	}
+
+	if assertsIntoNegatedPredicate && hadPriorState {
+		newlyTrue, newlyFalse, appliedRules := st.fullRecompute()
+		// Match the forward-delta path below: the facts just ingested are
+		// EDB, not derived conclusions, so they're excluded from
+		// Conclusions even though fullRecompute's before/after diff can't
+		// tell them apart from newly derived IDB tuples on its own.
+		derived := make([]Atom, 0, len(newlyTrue))
+		for _, a := range newlyTrue {
+			if _, isSeedFact := seed[relKey(a)]; !isSeedFact {
+				derived = append(derived, a)
+			}
+		}
+		return Result{Conclusions: derived, Retracted: newlyFalse, AppliedRules: appliedRules, provenance: st.provenance}
+	}
+
+	for k, v := range seed {
+		st.all[k] = v
+	}
+
+	// existedBefore is captured after merging the new facts themselves, so
+	// Conclusions below reports only tuples *derived* by this call — the
+	// facts just ingested are never reported back as conclusions, matching
+	// Evaluate's one-shot behavior.
+	existedBefore := make(map[string]bool, len(st.all))
+	for k := range st.all {
+		existedBefore[k] = true
+	}
+
+	firedRules := map[string]bool{}
+	recordDerivation := newDerivationRecorder(st.provenance)
+
+	// changedThisCall accumulates every tuple that became true since this
+	// Add call started — the new facts plus everything derived by strata
+	// processed so far — so that a later stratum whose rules depend only
+	// on an earlier stratum's IDB predicate (no EDB literal in the body)
+	// still has its first round seeded with something to join against.
+	changedThisCall := relation{}
+	for k, v := range seed {
+		changedThisCall[k] = v
+	}
+
+	for _, stratum := range st.engine.rules {
+		prevDelta := relation{}
+		for k, v := range changedThisCall {
+			prevDelta[k] = v
+		}
+
+		for {
+			roundNew := relation{}
+			for i := range stratum {
+				rule := &stratum[i]
+				for _, binding := range joinBody(rule.Body, st.all, prevDelta, true) {
+					head := groundAtom(rule.Head, binding)
+					key := relKey(head)
+					if _, exists := st.all[key]; exists {
+						recordDerivation(key, rule, binding)
+						continue
+					}
+					if _, exists := roundNew[key]; exists {
+						recordDerivation(key, rule, binding)
+						continue
+					}
+					roundNew[key] = head
+					firedRules[rule.String()] = true
+					recordDerivation(key, rule, binding)
+				}
+			}
+			if len(roundNew) == 0 {
+				break
+			}
+			for k, v := range roundNew {
+				st.all[k] = v
+				changedThisCall[k] = v
+			}
+			prevDelta = roundNew
+		}
+	}
+
+	newTuples := make([]Atom, 0)
+	for k, v := range st.all {
+		if !existedBefore[k] {
+			newTuples = append(newTuples, v)
+		}
+	}
+
+	rules := make([]string, 0, len(firedRules))
+	for r := range firedRules {
+		rules = append(rules, r)
+	}
+
+	return Result{Conclusions: newTuples, AppliedRules: rules, provenance: st.provenance}
+}
+
+// Retract removes facts from the session's EDB and recomputes the fixpoint
+// from the remaining base facts, so that any derived tuple whose only
+// support depended on a retracted fact — directly or transitively — is
+// dropped along with it (truth maintenance via re-derivation, rather than
+// incremental support-counting). It returns the tuples (EDB or IDB) that
+// were true before this call and are no longer true afterward.
+func (st *State) Retract(facts []Atom) Result {
+	for _, f := range facts {
+		delete(st.edb, relKey(f))
+	}
+	_, newlyFalse, appliedRules := st.fullRecompute()
+	return Result{Conclusions: newlyFalse, AppliedRules: appliedRules, provenance: st.provenance}
+}
+
+// fullRecompute recomputes st.all and st.provenance from scratch against
+// the current st.edb — a full, non-incremental evaluation, safe
+// regardless of what negation any tuple in st.edb feeds — and reports
+// the difference from the state before the call: tuples that became
+// newly true, and tuples that became newly false. Both Retract and Add
+// (when a forward delta pass would be unsound; see Add) use this as
+// their truth-maintenance fallback, though each packages the two lists
+// into Result differently to match its own documented contract.
+func (st *State) fullRecompute() (newlyTrue, newlyFalse []Atom, appliedRules []string) {
+	before := st.all
+	remaining := make([]Atom, 0, len(st.edb))
+	for _, f := range st.edb {
+		remaining = append(remaining, f)
+	}
+
+	fresh := st.engine.Evaluate(remaining)
+	all := relation{}
+	for _, f := range remaining {
+		all[relKey(f)] = f
+	}
+	for _, c := range fresh.Conclusions {
+		all[relKey(c)] = c
+	}
+	st.all = all
+	st.provenance = fresh.provenance
+
+	for k, v := range st.all {
+		if _, existed := before[k]; !existed {
+			newlyTrue = append(newlyTrue, v)
+		}
+	}
+	for k, v := range before {
+		if _, stillTrue := st.all[k]; !stillTrue {
+			newlyFalse = append(newlyFalse, v)
+		}
+	}
+
+	return newlyTrue, newlyFalse, fresh.AppliedRules
+}
+
+func premisesOf(body []Literal, b binding) []Atom {
+	premises := make([]Atom, 0, len(body))
+	for _, lit := range body {
+		if lit.Negated {
+			continue
+		}
+		premises = append(premises, groundAtom(lit.Atom, b))
+	}
+	return premises
+}
+
+// joinBody enumerates every binding that satisfies every literal in body
+// against the stable relation `all`, requiring at least one positive
+// literal to match a tuple in `delta` when semiNaive is true (so that a
+// rule instance is only re-derived once per newly available tuple, not on
+// every round).
+func joinBody(body []Literal, all, delta relation, semiNaive bool) []binding {
+	bindings := []binding{{}}
+	for _, lit := range body {
+		if lit.Negated {
+			var next []binding
+			for _, b := range bindings {
+				ground := groundAtom(lit.Atom, b)
+				if _, exists := all[relKey(ground)]; !exists {
+					next = append(next, b)
+				}
+			}
+			bindings = next
+			continue
+		}
+
+		var next []binding
+		for _, b := range bindings {
+			for _, candidate := range all {
+				nb, ok := unify(lit.Atom, candidate, b)
+				if ok {
+					next = append(next, nb)
+				}
+			}
+		}
+		bindings = next
+		if len(bindings) == 0 {
+			return nil
+		}
+	}
+
+	if !semiNaive {
+		return bindings
+	}
+	// Semi-naive restriction: keep only bindings where at least one
+	// positive literal grounds to a tuple that is new this round (in
+	// delta), so each rule instance is derived exactly once, the round it
+	// first becomes satisfiable.
+	var result []binding
+	for _, b := range bindings {
+		touchesDelta := false
+		for _, lit := range body {
+			if lit.Negated {
+				continue
+			}
+			ground := groundAtom(lit.Atom, b)
+			if _, ok := delta[relKey(ground)]; ok {
+				touchesDelta = true
+				break
+			}
+		}
+		if touchesDelta {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// unify extends binding b so that lit's arguments match candidate's
+// arguments positionally, returning ok=false on conflict or arity
+// mismatch.
+func unify(lit Atom, candidate Atom, b binding) (binding, bool) {
+	if lit.Predicate != candidate.Predicate || len(lit.Args) != len(candidate.Args) {
+		return nil, false
+	}
+	nb := make(binding, len(b)+len(lit.Args))
+	for k, v := range b {
+		nb[k] = v
+	}
+	for i, arg := range lit.Args {
+		val := candidate.Args[i].Value
+		if !arg.Variable {
+			if arg.Value != val {
+				return nil, false
+			}
+			continue
+		}
+		if existing, ok := nb[arg.Value]; ok {
+			if existing != val {
+				return nil, false
+			}
+			continue
+		}
+		nb[arg.Value] = val
+	}
+	return nb, true
+}
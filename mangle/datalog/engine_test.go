@@ -0,0 +1,282 @@
+package datalog
+
+import (
+	"sort"
+	"testing"
+)
+
+func mustParse(t *testing.T, src string) *Program {
+	t.Helper()
+	prog, err := Parse(src)
+	if err != nil {
+		t.Fatalf("Parse(%q) failed: %v", src, err)
+	}
+	return prog
+}
+
+func conclusionStrings(r Result) []string {
+	out := make([]string, 0, len(r.Conclusions))
+	for _, a := range r.Conclusions {
+		out = append(out, a.String())
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestTransitiveClosure(t *testing.T) {
+	prog := mustParse(t, `
+		edge(a, b).
+		edge(b, c).
+		edge(c, d).
+		path(X, Y) :- edge(X, Y).
+		path(X, Y) :- edge(X, Z), path(Z, Y).
+	`)
+	eng, err := NewEngine(prog)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	result := eng.Evaluate(prog.Facts())
+
+	got := conclusionStrings(result)
+	want := []string{
+		"path(a, b)", "path(a, c)", "path(a, d)",
+		"path(b, c)", "path(b, d)",
+		"path(c, d)",
+	}
+	sort.Strings(want)
+	if len(got) != len(want) {
+		t.Fatalf("got %v conclusions, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("conclusion %d: got %q, want %q (full: %v)", i, got[i], want[i], got)
+		}
+	}
+}
+
+func TestMutualRecursion(t *testing.T) {
+	// even/odd defined mutually recursively over a linear successor chain.
+	prog := mustParse(t, `
+		succ(z, s1).
+		succ(s1, s2).
+		succ(s2, s3).
+		succ(s3, s4).
+		even(z).
+		even(X) :- succ(Y, X), odd(Y).
+		odd(X) :- succ(Y, X), even(Y).
+	`)
+	eng, err := NewEngine(prog)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	result := eng.Evaluate(prog.Facts())
+
+	want := map[string]bool{
+		"even(s2)": true,
+		"even(s4)": true,
+		"odd(s1)":  true,
+		"odd(s3)":  true,
+	}
+	got := map[string]bool{}
+	for _, s := range conclusionStrings(result) {
+		got[s] = true
+	}
+	for k := range want {
+		if !got[k] {
+			t.Errorf("missing expected conclusion %q, got %v", k, got)
+		}
+	}
+}
+
+func TestStratifiedNegation(t *testing.T) {
+	prog := mustParse(t, `
+		node(a).
+		node(b).
+		node(c).
+		edge(a, b).
+		reachable(X, Y) :- edge(X, Y).
+		reachable(X, Y) :- edge(X, Z), reachable(Z, Y).
+		unreachable(X, Y) :- node(X), node(Y), not reachable(X, Y).
+	`)
+	eng, err := NewEngine(prog)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	result := eng.Evaluate(prog.Facts())
+
+	got := map[string]bool{}
+	for _, s := range conclusionStrings(result) {
+		got[s] = true
+	}
+	if !got["unreachable(a, c)"] {
+		t.Errorf("expected unreachable(a, c), got %v", got)
+	}
+	if got["unreachable(a, b)"] {
+		t.Errorf("did not expect unreachable(a, b) since edge(a,b) holds, got %v", got)
+	}
+}
+
+func TestNegationInsideCycleRejected(t *testing.T) {
+	prog := mustParse(t, `
+		p(X) :- q(X), not p(X).
+		q(a).
+	`)
+	if _, err := NewEngine(prog); err == nil {
+		t.Fatal("expected stratification error for negation inside a recursive cycle, got nil")
+	}
+}
+
+func TestUnsafeRuleRejectedAtParse(t *testing.T) {
+	_, err := Parse(`p(X) :- q(Y).`)
+	if err == nil {
+		t.Fatal("expected a range-restriction parse error, got nil")
+	}
+}
+
+func TestExplainTransitiveClosure(t *testing.T) {
+	prog := mustParse(t, `
+		edge(a, b).
+		edge(b, c).
+		path(X, Y) :- edge(X, Y).
+		path(X, Y) :- edge(X, Z), path(Z, Y).
+	`)
+	eng, err := NewEngine(prog)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	result := eng.Evaluate(prog.Facts())
+
+	target := Atom{Predicate: "path", Args: []Term{{Value: "a"}, {Value: "c"}}}
+	proofs, ok := result.Explain(target, 1)
+	if !ok {
+		t.Fatal("expected a proof for path(a, c)")
+	}
+	if len(proofs) != 1 {
+		t.Fatalf("expected the single shortest proof, got %d", len(proofs))
+	}
+	proof := proofs[0]
+	if proof.Rule == "" {
+		t.Fatal("expected an internal node with a rule, got a leaf")
+	}
+	if len(proof.Premises) != 2 {
+		t.Fatalf("expected 2 premises (edge(a,b), path(b,c)), got %d", len(proof.Premises))
+	}
+	for _, premise := range proof.Premises {
+		if premise.Fact.Predicate == "edge" && premise.Rule != "" {
+			t.Errorf("edge(a,b) is an EDB fact and should be a leaf, got rule %q", premise.Rule)
+		}
+	}
+
+	if _, ok := result.Explain(Atom{Predicate: "path", Args: []Term{{Value: "z"}, {Value: "z"}}}, 1); ok {
+		t.Fatal("did not expect a proof for a never-derived fact")
+	}
+}
+
+func TestCrossStratumDerivation(t *testing.T) {
+	// royal depends only on ancestor, an IDB predicate, with no EDB
+	// literal of its own in the body — so royal's stratum must be seeded
+	// with ancestor's conclusions, not just the original EDB facts.
+	prog := mustParse(t, `
+		parent(a, b).
+		parent(b, c).
+		ancestor(X, Y) :- parent(X, Y).
+		ancestor(X, Y) :- parent(X, Z), ancestor(Z, Y).
+		royal(X) :- ancestor(X, c).
+	`)
+	eng, err := NewEngine(prog)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	result := eng.Evaluate(prog.Facts())
+
+	got := map[string]bool{}
+	for _, s := range conclusionStrings(result) {
+		got[s] = true
+	}
+	if !got["royal(a)"] || !got["royal(b)"] {
+		t.Fatalf("expected royal(a) and royal(b), got %v", conclusionStrings(result))
+	}
+}
+
+func TestAddAssertingIntoNegatedPredicateRetractsDependents(t *testing.T) {
+	// p depends on the absence of r, so asserting r(a) after p(a) has
+	// already been derived must retract p(a) — a forward-only delta pass
+	// cannot see this, since it only ever adds tuples.
+	prog := mustParse(t, `
+		p(X) :- q(X), not r(X).
+	`)
+	eng, err := NewEngine(prog)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	session := eng.NewState()
+
+	qa, err := ParseFact("q(a)")
+	if err != nil {
+		t.Fatalf("ParseFact: %v", err)
+	}
+	result := session.Add([]Atom{qa})
+	if got := conclusionStrings(result); len(got) != 1 || got[0] != "p(a)" {
+		t.Fatalf("expected p(a) after q(a), got %v", got)
+	}
+
+	ra, err := ParseFact("r(a)")
+	if err != nil {
+		t.Fatalf("ParseFact: %v", err)
+	}
+	result = session.Add([]Atom{ra})
+
+	retracted := map[string]bool{}
+	for _, a := range result.Retracted {
+		retracted[a.String()] = true
+	}
+	if !retracted["p(a)"] {
+		t.Fatalf("expected asserting r(a) to retract p(a), got retracted=%v conclusions=%v", result.Retracted, result.Conclusions)
+	}
+
+	// The session's live state must agree: a fresh one-shot Evaluate
+	// against the same facts shouldn't derive p(a) either, and neither
+	// should the incrementally-maintained state still contain it.
+	fresh := eng.Evaluate([]Atom{qa, ra})
+	for _, c := range fresh.Conclusions {
+		if c.String() == "p(a)" {
+			t.Fatalf("p(a) should not hold once r(a) is asserted")
+		}
+	}
+}
+
+func TestExplainCyclicGraphDoesNotRecurseForever(t *testing.T) {
+	prog := mustParse(t, `
+		edge(a, b).
+		edge(b, a).
+		path(X, Y) :- edge(X, Y).
+		path(X, Y) :- edge(X, Z), path(Z, Y).
+	`)
+	eng, err := NewEngine(prog)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	result := eng.Evaluate(prog.Facts())
+
+	target := Atom{Predicate: "path", Args: []Term{{Value: "a"}, {Value: "b"}}}
+	proofs, ok := result.Explain(target, 1)
+	if !ok {
+		t.Fatal("expected a proof for path(a, b)")
+	}
+	if len(proofs) != 1 {
+		t.Fatalf("expected 1 proof, got %d", len(proofs))
+	}
+}
+
+func TestParseFact(t *testing.T) {
+	a, err := ParseFact(`human(socrates)`)
+	if err != nil {
+		t.Fatalf("ParseFact: %v", err)
+	}
+	if a.String() != "human(socrates)" {
+		t.Fatalf("got %q", a.String())
+	}
+	if _, err := ParseFact(`human(X)`); err == nil {
+		t.Fatal("expected error parsing non-ground fact")
+	}
+}
@@ -0,0 +1,102 @@
+package datalog
+
+// Justification is a single proof tree for a derived tuple: a leaf is an
+// original EDB fact (Rule == ""); an internal node carries the id (string
+// form) of the rule instance that derived Fact, the variable bindings it
+// fired with, and the justifications for each premise in its body.
+type Justification struct {
+	Fact     Atom
+	Rule     string
+	Bindings map[string]string
+	Premises []*Justification
+}
+
+// Explain returns up to maxProofs proof trees for fact, drawn from the
+// derivations recorded while producing r. found is false if fact was
+// never derived by a rule — either because it was supplied as an input
+// (EDB) fact rather than an IDB conclusion, or because it was never
+// reached at all.
+//
+// maxProofs <= 0 is treated as 1: by default Explain returns the
+// shortest proof (fewest premises, recursively), which is generally the
+// most useful explanation. Pass a larger maxProofs to see alternate
+// derivations of the same tuple.
+func (r Result) Explain(fact Atom, maxProofs int) (proofs []*Justification, found bool) {
+	if maxProofs <= 0 {
+		maxProofs = 1
+	}
+	key := relKey(fact)
+	derivations, ok := r.provenance[key]
+	if !ok {
+		return nil, false
+	}
+	// visited tracks the tuples on the current root-to-leaf path so a
+	// derivation cycle (e.g. path(a,b) provable via path(b,a) provable
+	// via path(a,b), over a cyclic edge relation) cuts the branch instead
+	// of recursing forever; it is not a global seen-set, since the same
+	// tuple legitimately appearing down two different branches (a
+	// diamond) is fine.
+	visited := map[string]bool{key: true}
+	all := make([]*Justification, 0, len(derivations))
+	for _, d := range derivations {
+		all = append(all, r.buildJustification(fact, d, visited))
+	}
+	sortJustificationsByWeight(all)
+	if len(all) > maxProofs {
+		all = all[:maxProofs]
+	}
+	return all, true
+}
+
+func (r Result) buildJustification(fact Atom, d *derivation, visited map[string]bool) *Justification {
+	j := &Justification{Fact: fact, Rule: d.rule.String(), Bindings: map[string]string(d.bindings)}
+	for _, premise := range d.premises {
+		j.Premises = append(j.Premises, r.explainPremise(premise, visited))
+	}
+	return j
+}
+
+// explainPremise returns the shortest-proof justification for a body
+// tuple: a leaf if it has no recorded derivation (it came from the EDB)
+// or is already on the current path (a cycle), otherwise the lightest of
+// its recorded derivations.
+func (r Result) explainPremise(fact Atom, visited map[string]bool) *Justification {
+	key := relKey(fact)
+	derivations, ok := r.provenance[key]
+	if !ok || len(derivations) == 0 || visited[key] {
+		return &Justification{Fact: fact}
+	}
+	visited[key] = true
+	defer delete(visited, key)
+
+	best := r.buildJustification(fact, derivations[0], visited)
+	bestWeight := weight(best)
+	for _, d := range derivations[1:] {
+		candidate := r.buildJustification(fact, d, visited)
+		if w := weight(candidate); w < bestWeight {
+			best, bestWeight = candidate, w
+		}
+	}
+	return best
+}
+
+// weight is the total number of leaves (EDB facts) in a proof tree,
+// used to pick the "shortest" proof among several derivations.
+func weight(j *Justification) int {
+	if len(j.Premises) == 0 {
+		return 1
+	}
+	total := 0
+	for _, p := range j.Premises {
+		total += weight(p)
+	}
+	return total
+}
+
+func sortJustificationsByWeight(js []*Justification) {
+	for i := 1; i < len(js); i++ {
+		for k := i; k > 0 && weight(js[k]) < weight(js[k-1]); k-- {
+			js[k], js[k-1] = js[k-1], js[k]
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package datalog
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokVar
+	tokNumber
+	tokString
+	tokLParen
+	tokRParen
+	tokComma
+	tokDot
+	tokImplies // ":-"
+	tokNot
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+	col  int
+}
+
+type lexError struct {
+	line, col int
+	msg       string
+}
+
+func (e *lexError) Error() string {
+	return fmt.Sprintf("line %d:%d: %s", e.line, e.col, e.msg)
+}
+
+// lexer tokenizes Datalog source: facts, rules, and the atoms/terms they
+// are built from (see package doc).
+type lexer struct {
+	src        []rune
+	pos        int
+	line, col  int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: []rune(src), line: 1, col: 1}
+}
+
+func (l *lexer) peek() rune {
+	if l.pos >= len(l.src) {
+		return 0
+	}
+	return l.src[l.pos]
+}
+
+func (l *lexer) peekAt(off int) rune {
+	if l.pos+off >= len(l.src) {
+		return 0
+	}
+	return l.src[l.pos+off]
+}
+
+func (l *lexer) advance() rune {
+	r := l.src[l.pos]
+	l.pos++
+	if r == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return r
+}
+
+func (l *lexer) skipTrivia() {
+	for l.pos < len(l.src) {
+		r := l.peek()
+		switch {
+		case unicode.IsSpace(r):
+			l.advance()
+		case r == '%':
+			for l.pos < len(l.src) && l.peek() != '\n' {
+				l.advance()
+			}
+		default:
+			return
+		}
+	}
+}
+
+// next returns the next token, or a *lexError.
+func (l *lexer) next() (token, error) {
+	l.skipTrivia()
+	startLine, startCol := l.line, l.col
+	if l.pos >= len(l.src) {
+		return token{kind: tokEOF, line: startLine, col: startCol}, nil
+	}
+
+	r := l.peek()
+	switch {
+	case r == '(':
+		l.advance()
+		return token{kind: tokLParen, text: "(", line: startLine, col: startCol}, nil
+	case r == ')':
+		l.advance()
+		return token{kind: tokRParen, text: ")", line: startLine, col: startCol}, nil
+	case r == ',':
+		l.advance()
+		return token{kind: tokComma, text: ",", line: startLine, col: startCol}, nil
+	case r == '.':
+		l.advance()
+		return token{kind: tokDot, text: ".", line: startLine, col: startCol}, nil
+	case r == ':' && l.peekAt(1) == '-':
+		l.advance()
+		l.advance()
+		return token{kind: tokImplies, text: ":-", line: startLine, col: startCol}, nil
+	case r == '"':
+		return l.lexString(startLine, startCol)
+	case r == '-' && unicode.IsDigit(l.peekAt(1)):
+		return l.lexNumber(startLine, startCol)
+	case unicode.IsDigit(r):
+		return l.lexNumber(startLine, startCol)
+	case r == '_' || unicode.IsLetter(r):
+		return l.lexWord(startLine, startCol)
+	default:
+		return token{}, &lexError{startLine, startCol, fmt.Sprintf("unexpected character %q", r)}
+	}
+}
+
+func (l *lexer) lexString(line, col int) (token, error) {
+	l.advance() // opening quote
+	var sb strings.Builder
+	for {
+		if l.pos >= len(l.src) {
+			return token{}, &lexError{line, col, "unterminated string literal"}
+		}
+		r := l.advance()
+		if r == '"' {
+			return token{kind: tokString, text: sb.String(), line: line, col: col}, nil
+		}
+		if r == '\n' {
+			return token{}, &lexError{line, col, "unterminated string literal"}
+		}
+		sb.WriteRune(r)
+	}
+}
+
+func (l *lexer) lexNumber(line, col int) (token, error) {
+	var sb strings.Builder
+	if l.peek() == '-' {
+		sb.WriteRune(l.advance())
+	}
+	for l.pos < len(l.src) && unicode.IsDigit(l.peek()) {
+		sb.WriteRune(l.advance())
+	}
+	return token{kind: tokNumber, text: sb.String(), line: line, col: col}, nil
+}
+
+func (l *lexer) lexWord(line, col int) (token, error) {
+	var sb strings.Builder
+	for l.pos < len(l.src) && (l.peek() == '_' || unicode.IsLetter(l.peek()) || unicode.IsDigit(l.peek())) {
+		sb.WriteRune(l.advance())
+	}
+	word := sb.String()
+	if word == "not" {
+		return token{kind: tokNot, text: word, line: line, col: col}, nil
+	}
+	first := []rune(word)[0]
+	if first == '_' || unicode.IsUpper(first) {
+		return token{kind: tokVar, text: word, line: line, col: col}, nil
+	}
+	return token{kind: tokIdent, text: word, line: line, col: col}, nil
+}
@@ -0,0 +1,236 @@
+package datalog
+
+import "fmt"
+
+// ParseError is returned by Parse when the source is not well-formed
+// Datalog (see package doc) or a rule fails the range-restriction safety
+// check. It is safe to format with %v or to surface directly in an
+// ErrorMessage field.
+type ParseError struct {
+	Line, Col int
+	Msg       string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d:%d: %s", e.Line, e.Col, e.Msg)
+}
+
+// Parse parses a full Datalog program: zero or more facts and rules.
+func Parse(src string) (*Program, error) {
+	p := &parser{lex: newLexer(src)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	var rules []Rule
+	for p.tok.kind != tokEOF {
+		r, err := p.parseRule()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+	return &Program{Rules: rules}, nil
+}
+
+// ParseFact parses a single ground atom, with or without a trailing '.'.
+// It is used to ingest facts supplied at request time rather than loaded
+// from a rules file.
+func ParseFact(src string) (Atom, error) {
+	p := &parser{lex: newLexer(src)}
+	if err := p.advance(); err != nil {
+		return Atom{}, err
+	}
+	a, err := p.parseAtom()
+	if err != nil {
+		return Atom{}, err
+	}
+	if p.tok.kind == tokDot {
+		if err := p.advance(); err != nil {
+			return Atom{}, err
+		}
+	}
+	if p.tok.kind != tokEOF {
+		return Atom{}, p.errorf("unexpected trailing input after fact")
+	}
+	if !a.Ground() {
+		return Atom{}, p.errorf("fact %s must be ground", a)
+	}
+	return a, nil
+}
+
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+func (p *parser) advance() error {
+	t, err := p.lex.next()
+	if err != nil {
+		le := err.(*lexError)
+		return &ParseError{Line: le.line, Col: le.col, Msg: le.msg}
+	}
+	p.tok = t
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &ParseError{Line: p.tok.line, Col: p.tok.col, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) expect(k tokenKind, what string) (token, error) {
+	if p.tok.kind != k {
+		return token{}, p.errorf("expected %s, found %q", what, p.tok.text)
+	}
+	t := p.tok
+	if err := p.advance(); err != nil {
+		return token{}, err
+	}
+	return t, nil
+}
+
+// parseRule parses `head ( ':-' body )? '.'`.
+func (p *parser) parseRule() (Rule, error) {
+	head, err := p.parseAtom()
+	if err != nil {
+		return Rule{}, err
+	}
+	var body []Literal
+	if p.tok.kind == tokImplies {
+		if err := p.advance(); err != nil {
+			return Rule{}, err
+		}
+		body, err = p.parseBody()
+		if err != nil {
+			return Rule{}, err
+		}
+	}
+	if _, err := p.expect(tokDot, "'.'"); err != nil {
+		return Rule{}, err
+	}
+	rule := Rule{Head: head, Body: body}
+	if err := checkSafety(rule); err != nil {
+		return Rule{}, &ParseError{Msg: err.Error()}
+	}
+	return rule, nil
+}
+
+func (p *parser) parseBody() ([]Literal, error) {
+	var lits []Literal
+	for {
+		lit, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		lits = append(lits, lit)
+		if p.tok.kind != tokComma {
+			break
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return lits, nil
+}
+
+func (p *parser) parseLiteral() (Literal, error) {
+	negated := false
+	if p.tok.kind == tokNot {
+		negated = true
+		if err := p.advance(); err != nil {
+			return Literal{}, err
+		}
+	}
+	atom, err := p.parseAtom()
+	if err != nil {
+		return Literal{}, err
+	}
+	return Literal{Atom: atom, Negated: negated}, nil
+}
+
+func (p *parser) parseAtom() (Atom, error) {
+	pred, err := p.expect(tokIdent, "predicate name")
+	if err != nil {
+		return Atom{}, err
+	}
+	if _, err := p.expect(tokLParen, "'('"); err != nil {
+		return Atom{}, err
+	}
+	var args []Term
+	if p.tok.kind != tokRParen {
+		args, err = p.parseTermList()
+		if err != nil {
+			return Atom{}, err
+		}
+	}
+	if _, err := p.expect(tokRParen, "')'"); err != nil {
+		return Atom{}, err
+	}
+	return Atom{Predicate: pred.text, Args: args}, nil
+}
+
+func (p *parser) parseTermList() ([]Term, error) {
+	var terms []Term
+	for {
+		t, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, t)
+		if p.tok.kind != tokComma {
+			break
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return terms, nil
+}
+
+func (p *parser) parseTerm() (Term, error) {
+	switch p.tok.kind {
+	case tokVar:
+		t := Term{Value: p.tok.text, Variable: true}
+		return t, p.advance()
+	case tokIdent, tokNumber, tokString:
+		t := Term{Value: p.tok.text}
+		return t, p.advance()
+	default:
+		return Term{}, p.errorf("expected a term, found %q", p.tok.text)
+	}
+}
+
+// checkSafety enforces range-restriction: every variable in the head, and
+// every variable in a negated body literal, must also occur in some
+// positive body literal.
+func checkSafety(r Rule) error {
+	if r.IsFact() {
+		return nil
+	}
+	bound := map[string]bool{}
+	for _, lit := range r.Body {
+		if lit.Negated {
+			continue
+		}
+		for _, arg := range lit.Atom.Args {
+			if arg.Variable {
+				bound[arg.Value] = true
+			}
+		}
+	}
+	for _, arg := range r.Head.Args {
+		if arg.Variable && !bound[arg.Value] {
+			return fmt.Errorf("rule %s is not range-restricted: head variable %s does not occur in a positive body literal", r, arg.Value)
+		}
+	}
+	for _, lit := range r.Body {
+		if !lit.Negated {
+			continue
+		}
+		for _, arg := range lit.Atom.Args {
+			if arg.Variable && !bound[arg.Value] {
+				return fmt.Errorf("rule %s is not range-restricted: negated variable %s does not occur in a positive body literal", r, arg.Value)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package datalog
+
+import "testing"
+
+func TestStateIncrementalAdd(t *testing.T) {
+	prog := mustParse(t, `
+		path(X, Y) :- edge(X, Y).
+		path(X, Y) :- edge(X, Z), path(Z, Y).
+	`)
+	eng, err := NewEngine(prog)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	st := eng.NewState()
+
+	first := st.Add([]Atom{mustFact(t, "edge(a, b)")})
+	if got := conclusionStrings(first); len(got) != 1 || got[0] != "path(a, b)" {
+		t.Fatalf("after edge(a,b): got %v, want [path(a, b)]", got)
+	}
+
+	second := st.Add([]Atom{mustFact(t, "edge(b, c)")})
+	got := map[string]bool{}
+	for _, s := range conclusionStrings(second) {
+		got[s] = true
+	}
+	if !got["path(b, c)"] || !got["path(a, c)"] {
+		t.Fatalf("after edge(b,c): got %v, want path(b, c) and path(a, c)", conclusionStrings(second))
+	}
+	if got["path(a, b)"] {
+		t.Errorf("path(a, b) was already known before this Add and should not be reported again, got %v", conclusionStrings(second))
+	}
+}
+
+func TestStateRetractRemovesUnsupportedConclusions(t *testing.T) {
+	prog := mustParse(t, `
+		path(X, Y) :- edge(X, Y).
+		path(X, Y) :- edge(X, Z), path(Z, Y).
+	`)
+	eng, err := NewEngine(prog)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	st := eng.NewState()
+	st.Add([]Atom{mustFact(t, "edge(a, b)"), mustFact(t, "edge(b, c)")})
+
+	result := st.Retract([]Atom{mustFact(t, "edge(b, c)")})
+	removed := map[string]bool{}
+	for _, s := range conclusionStrings(result) {
+		removed[s] = true
+	}
+	if !removed["path(b, c)"] || !removed["path(a, c)"] {
+		t.Fatalf("retracting edge(b,c) should drop path(b, c) and path(a, c), got %v", conclusionStrings(result))
+	}
+	if removed["path(a, b)"] {
+		t.Errorf("path(a, b) still has support from edge(a,b) and should not be retracted, got %v", conclusionStrings(result))
+	}
+
+	// The surviving path(a, b) must still be derivable when new facts are
+	// layered on top, proving the session's state stayed consistent.
+	more := st.Add([]Atom{mustFact(t, "edge(b, d)")})
+	moreSet := map[string]bool{}
+	for _, s := range conclusionStrings(more) {
+		moreSet[s] = true
+	}
+	if !moreSet["path(b, d)"] || !moreSet["path(a, d)"] {
+		t.Fatalf("expected path(b, d) and path(a, d) after adding edge(b,d), got %v", conclusionStrings(more))
+	}
+}
+
+func mustFact(t *testing.T, src string) Atom {
+	t.Helper()
+	a, err := ParseFact(src)
+	if err != nil {
+		t.Fatalf("ParseFact(%q): %v", src, err)
+	}
+	return a
+}
@@ -0,0 +1,138 @@
+package datalog
+
+import "fmt"
+
+// depEdge is an edge in the predicate dependency graph: the predicate
+// defined by a rule depends on every predicate referenced in its body.
+type depEdge struct {
+	to       string
+	negative bool
+}
+
+// stratify partitions a program's IDB rules into strata: rules for
+// predicates in stratum i may only negate predicates fully derived in an
+// earlier stratum. It rejects programs where negation occurs inside a
+// dependency cycle, since such programs have no well-founded stratified
+// model.
+//
+// The algorithm computes the predicate dependency graph (an edge p -> q,
+// marked negative if q appears negated in a rule defining p), finds its
+// strongly connected components via Tarjan's algorithm, then orders the
+// condensation topologically. Any negative edge whose endpoints share an
+// SCC is a negation-in-a-cycle error.
+func stratify(rules []Rule) ([][]Rule, error) {
+	graph := map[string][]depEdge{}
+	addPred := func(p string) {
+		if _, ok := graph[p]; !ok {
+			graph[p] = nil
+		}
+	}
+
+	for _, r := range rules {
+		addPred(r.Head.Predicate)
+		for _, lit := range r.Body {
+			addPred(lit.Atom.Predicate)
+			graph[r.Head.Predicate] = append(graph[r.Head.Predicate], depEdge{to: lit.Atom.Predicate, negative: lit.Negated})
+		}
+	}
+
+	sccOf, order := tarjanSCC(graph)
+
+	for p, edges := range graph {
+		for _, e := range edges {
+			if e.negative && sccOf[p] == sccOf[e.to] {
+				return nil, fmt.Errorf("predicate %q is negated within a recursive cycle through %q; program is not stratifiable", e.to, p)
+			}
+		}
+	}
+
+	rulesByPred := map[string][]Rule{}
+	for _, r := range rules {
+		rulesByPred[r.Head.Predicate] = append(rulesByPred[r.Head.Predicate], r)
+	}
+
+	strata := make([][]Rule, 0, len(order))
+	for _, sccPreds := range order {
+		var stratum []Rule
+		for _, p := range sccPreds {
+			stratum = append(stratum, rulesByPred[p]...)
+		}
+		if len(stratum) > 0 {
+			strata = append(strata, stratum)
+		}
+	}
+	return strata, nil
+}
+
+// tarjanSCC returns, for each node, the index of its strongly connected
+// component, and the components themselves in dependency order: by
+// construction, Tarjan's algorithm finishes (and appends to the result) a
+// component only after every component it depends on has already finished.
+func tarjanSCC(graph map[string][]depEdge) (map[string]int, [][]string) {
+	index := map[string]int{}
+	lowlink := map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	nextIndex := 0
+	sccOf := map[string]int{}
+	var components [][]string
+
+	var nodes []string
+	for n := range graph {
+		nodes = append(nodes, n)
+	}
+
+	var strongconnect func(v string)
+	strongconnect = func(v string) {
+		index[v] = nextIndex
+		lowlink[v] = nextIndex
+		nextIndex++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, e := range graph[v] {
+			w := e.to
+			if _, seen := index[w]; !seen {
+				strongconnect(w)
+				if lowlink[w] < lowlink[v] {
+					lowlink[v] = lowlink[w]
+				}
+			} else if onStack[w] {
+				if index[w] < lowlink[v] {
+					lowlink[v] = index[w]
+				}
+			}
+		}
+
+		if lowlink[v] == index[v] {
+			var comp []string
+			for {
+				n := len(stack) - 1
+				w := stack[n]
+				stack = stack[:n]
+				onStack[w] = false
+				comp = append(comp, w)
+				if w == v {
+					break
+				}
+			}
+			compIdx := len(components)
+			for _, w := range comp {
+				sccOf[w] = compIdx
+			}
+			components = append(components, comp)
+		}
+	}
+
+	for _, n := range nodes {
+		if _, seen := index[n]; !seen {
+			strongconnect(n)
+		}
+	}
+
+	// Tarjan finishes (and appends) a component only once every component
+	// it depends on has already finished, so `components` is already in
+	// dependency order: a predicate's dependencies occupy earlier strata
+	// than the predicate itself.
+	return sccOf, components
+}
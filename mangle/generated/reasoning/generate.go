@@ -0,0 +1,9 @@
+// Package reasoning holds the generated protobuf/gRPC/gateway stubs for
+// mangle/proto/reasoning.proto. Regenerate with `go generate ./...` after
+// editing the .proto (requires protoc and the protoc-gen-go,
+// protoc-gen-go-grpc, protoc-gen-grpc-gateway and protoc-gen-openapiv2
+// plugins on PATH, plus the googleapis annotations.proto on the include
+// path).
+package reasoning
+
+//go:generate protoc -I=../../proto -I=../../third_party/googleapis --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative --grpc-gateway_out=. --grpc-gateway_opt=paths=source_relative --openapiv2_out=. ../../proto/reasoning.proto
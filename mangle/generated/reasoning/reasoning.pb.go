@@ -0,0 +1,164 @@
+// Code generated by protoc-gen-go normally lives here. protoc and the
+// googleapis annotations.proto include path are not available in every
+// environment this series was developed in, so these message types were
+// checked in by hand from proto/reasoning.proto instead of via
+// `go generate`; regenerate with the command in generate.go once protoc
+// is on PATH and this file will be replaced byte-for-byte.
+//
+// source: proto/reasoning.proto
+
+package reasoning
+
+import "fmt"
+
+// ReasoningRequest is a batch of facts (and, on StreamApplyRules,
+// retractions) to evaluate against the currently loaded program.
+type ReasoningRequest struct {
+	Facts []string `protobuf:"bytes,1,rep,name=facts,proto3" json:"facts,omitempty"`
+
+	// Explain, when true, makes ApplyRules additionally populate
+	// justifications for every returned conclusion.
+	Explain bool `protobuf:"varint,2,opt,name=explain,proto3" json:"explain,omitempty"`
+
+	// MaxProofs caps how many alternate proof trees are returned per
+	// conclusion when Explain is set. 0 means "the single shortest proof".
+	MaxProofs int32 `protobuf:"varint,3,opt,name=max_proofs,json=maxProofs,proto3" json:"max_proofs,omitempty"`
+
+	// Retract lists facts to remove from this call's EDB. Only meaningful
+	// on StreamApplyRules, where it triggers truth maintenance: any
+	// conclusion whose support depended on a retracted fact is dropped and
+	// reported back via ReasoningResponse.RetractedConclusions.
+	Retract []string `protobuf:"bytes,4,rep,name=retract,proto3" json:"retract,omitempty"`
+}
+
+func (m *ReasoningRequest) Reset()         { *m = ReasoningRequest{} }
+func (m *ReasoningRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*ReasoningRequest) ProtoMessage()    {}
+
+// ReasoningResponse carries the outcome of one ApplyRules/StreamApplyRules
+// batch: the conclusions newly derived (and, on StreamApplyRules, newly
+// retracted), the rules that fired, and — when requested — their proofs.
+type ReasoningResponse struct {
+	Conclusions  []string `protobuf:"bytes,1,rep,name=conclusions,proto3" json:"conclusions,omitempty"`
+	AppliedRules []string `protobuf:"bytes,2,rep,name=applied_rules,json=appliedRules,proto3" json:"applied_rules,omitempty"`
+	Success      bool     `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage string   `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+
+	// Justifications maps a conclusion (its string form, matching an entry
+	// in Conclusions) to the proof tree(s) that derived it. Only populated
+	// when the request set Explain = true.
+	Justifications map[string]*JustificationList `protobuf:"bytes,5,rep,name=justifications,proto3" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3" json:"justifications,omitempty"`
+
+	// RetractedConclusions lists tuples that lost support and stopped
+	// being true as a result of this batch's Retract field. Only ever
+	// populated on StreamApplyRules responses.
+	RetractedConclusions []string `protobuf:"bytes,6,rep,name=retracted_conclusions,json=retractedConclusions,proto3" json:"retracted_conclusions,omitempty"`
+}
+
+func (m *ReasoningResponse) Reset()         { *m = ReasoningResponse{} }
+func (m *ReasoningResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*ReasoningResponse) ProtoMessage()    {}
+
+// JustificationList wraps a repeated field so it can be a map value.
+type JustificationList struct {
+	Proofs []*Justification `protobuf:"bytes,1,rep,name=proofs,proto3" json:"proofs,omitempty"`
+}
+
+func (m *JustificationList) Reset()         { *m = JustificationList{} }
+func (m *JustificationList) String() string { return fmt.Sprintf("%+v", *m) }
+func (*JustificationList) ProtoMessage()    {}
+
+// Justification is one proof tree for a derived fact. A leaf (Rule and
+// Bindings both empty) is an original EDB fact; an internal node names
+// the rule instance that derived Fact and the bindings it fired with.
+type Justification struct {
+	Fact     string            `protobuf:"bytes,1,opt,name=fact,proto3" json:"fact,omitempty"`
+	Rule     string            `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+	Bindings map[string]string `protobuf:"bytes,3,rep,name=bindings,proto3" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3" json:"bindings,omitempty"`
+	Premises []*Justification  `protobuf:"bytes,4,rep,name=premises,proto3" json:"premises,omitempty"`
+}
+
+func (m *Justification) Reset()         { *m = Justification{} }
+func (m *Justification) String() string { return fmt.Sprintf("%+v", *m) }
+func (*Justification) ProtoMessage()    {}
+
+type LoadRulesRequest struct {
+	RulesContent string `protobuf:"bytes,1,opt,name=rules_content,json=rulesContent,proto3" json:"rules_content,omitempty"`
+}
+
+func (m *LoadRulesRequest) Reset()         { *m = LoadRulesRequest{} }
+func (m *LoadRulesRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*LoadRulesRequest) ProtoMessage()    {}
+
+type LoadRulesResponse struct {
+	Success      bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage string `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	RulesLoaded  int32  `protobuf:"varint,3,opt,name=rules_loaded,json=rulesLoaded,proto3" json:"rules_loaded,omitempty"`
+}
+
+func (m *LoadRulesResponse) Reset()         { *m = LoadRulesResponse{} }
+func (m *LoadRulesResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*LoadRulesResponse) ProtoMessage()    {}
+
+type HealthCheckRequest struct{}
+
+func (m *HealthCheckRequest) Reset()         { *m = HealthCheckRequest{} }
+func (m *HealthCheckRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*HealthCheckRequest) ProtoMessage()    {}
+
+type HealthCheckResponse struct {
+	Healthy bool   `protobuf:"varint,1,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	Status  string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (m *HealthCheckResponse) Reset()         { *m = HealthCheckResponse{} }
+func (m *HealthCheckResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*HealthCheckResponse) ProtoMessage()    {}
+
+type ExplainConclusionRequest struct {
+	Conclusion string `protobuf:"bytes,1,opt,name=conclusion,proto3" json:"conclusion,omitempty"`
+	MaxProofs  int32  `protobuf:"varint,2,opt,name=max_proofs,json=maxProofs,proto3" json:"max_proofs,omitempty"`
+}
+
+func (m *ExplainConclusionRequest) Reset()         { *m = ExplainConclusionRequest{} }
+func (m *ExplainConclusionRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*ExplainConclusionRequest) ProtoMessage()    {}
+
+type ExplainConclusionResponse struct {
+	Found        bool             `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Proofs       []*Justification `protobuf:"bytes,2,rep,name=proofs,proto3" json:"proofs,omitempty"`
+	ErrorMessage string           `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (m *ExplainConclusionResponse) Reset()         { *m = ExplainConclusionResponse{} }
+func (m *ExplainConclusionResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*ExplainConclusionResponse) ProtoMessage()    {}
+
+// SubscribeConclusionsRequest selects which derived tuples a
+// SubscribeConclusions call should receive.
+type SubscribeConclusionsRequest struct {
+	// Predicate is the name of the derived predicate to watch, e.g. "path".
+	Predicate string `protobuf:"bytes,1,opt,name=predicate,proto3" json:"predicate,omitempty"`
+
+	// Filter, if set, is a substring that a tuple's string form must
+	// contain to be delivered.
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (m *SubscribeConclusionsRequest) Reset()         { *m = SubscribeConclusionsRequest{} }
+func (m *SubscribeConclusionsRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*SubscribeConclusionsRequest) ProtoMessage()    {}
+
+// ConclusionEvent is one tuple becoming newly true or newly false,
+// delivered by SubscribeConclusions.
+type ConclusionEvent struct {
+	Conclusion string `protobuf:"bytes,1,opt,name=conclusion,proto3" json:"conclusion,omitempty"`
+
+	// Retracted is true if Conclusion just lost support and stopped being
+	// true, false if it was just newly derived.
+	Retracted bool `protobuf:"varint,2,opt,name=retracted,proto3" json:"retracted,omitempty"`
+}
+
+func (m *ConclusionEvent) Reset()         { *m = ConclusionEvent{} }
+func (m *ConclusionEvent) String() string { return fmt.Sprintf("%+v", *m) }
+func (*ConclusionEvent) ProtoMessage()    {}
@@ -0,0 +1,72 @@
+// Code generated by protoc-gen-grpc-gateway normally lives here; checked
+// in by hand for the reason given at the top of reasoning.pb.go.
+// Regenerate with the command in generate.go once protoc is available.
+//
+// source: proto/reasoning.proto
+
+package reasoning
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// RegisterReasoningServiceHandler registers the http handlers for the
+// ApplyRules, LoadRules and HealthCheck RPCs (the only ones annotated
+// with google.api.http in reasoning.proto) onto mux, proxying each
+// request over conn. ExplainConclusion and the two streaming RPCs have
+// no HTTP annotation and are reachable only over gRPC.
+func RegisterReasoningServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+	client := NewReasoningServiceClient(conn)
+
+	if err := mux.HandlePath(http.MethodPost, "/v1/reason", func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		req := new(ReasoningRequest)
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp, err := client.ApplyRules(r.Context(), req)
+		writeGatewayResponse(w, resp, err)
+	}); err != nil {
+		return err
+	}
+
+	if err := mux.HandlePath(http.MethodPost, "/v1/rules", func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		req := new(LoadRulesRequest)
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp, err := client.LoadRules(r.Context(), req)
+		writeGatewayResponse(w, resp, err)
+	}); err != nil {
+		return err
+	}
+
+	if err := mux.HandlePath(http.MethodGet, "/v1/health", func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		resp, err := client.HealthCheck(r.Context(), &HealthCheckRequest{})
+		writeGatewayResponse(w, resp, err)
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// writeGatewayResponse marshals resp as JSON, or translates a gRPC error
+// into an HTTP status the way runtime.DefaultHTTPErrorHandler would.
+func writeGatewayResponse(w http.ResponseWriter, resp interface{}, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), runtime.HTTPStatusFromCode(status.Code(err)))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if encErr := json.NewEncoder(w).Encode(resp); encErr != nil {
+		http.Error(w, encErr.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,333 @@
+// Code generated by protoc-gen-go-grpc normally lives here; checked in by
+// hand for the reason given at the top of reasoning.pb.go. Regenerate
+// with the command in generate.go once protoc is available.
+//
+// source: proto/reasoning.proto
+
+package reasoning
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	ReasoningService_ApplyRules_FullMethodName          = "/reasoning.ReasoningService/ApplyRules"
+	ReasoningService_LoadRules_FullMethodName           = "/reasoning.ReasoningService/LoadRules"
+	ReasoningService_HealthCheck_FullMethodName         = "/reasoning.ReasoningService/HealthCheck"
+	ReasoningService_ExplainConclusion_FullMethodName   = "/reasoning.ReasoningService/ExplainConclusion"
+	ReasoningService_StreamApplyRules_FullMethodName    = "/reasoning.ReasoningService/StreamApplyRules"
+	ReasoningService_SubscribeConclusions_FullMethodName = "/reasoning.ReasoningService/SubscribeConclusions"
+)
+
+// ReasoningServiceClient is the client API for ReasoningService.
+type ReasoningServiceClient interface {
+	ApplyRules(ctx context.Context, in *ReasoningRequest, opts ...grpc.CallOption) (*ReasoningResponse, error)
+	LoadRules(ctx context.Context, in *LoadRulesRequest, opts ...grpc.CallOption) (*LoadRulesResponse, error)
+	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
+	ExplainConclusion(ctx context.Context, in *ExplainConclusionRequest, opts ...grpc.CallOption) (*ExplainConclusionResponse, error)
+	StreamApplyRules(ctx context.Context, opts ...grpc.CallOption) (ReasoningService_StreamApplyRulesClient, error)
+	SubscribeConclusions(ctx context.Context, in *SubscribeConclusionsRequest, opts ...grpc.CallOption) (ReasoningService_SubscribeConclusionsClient, error)
+}
+
+type reasoningServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReasoningServiceClient(cc grpc.ClientConnInterface) ReasoningServiceClient {
+	return &reasoningServiceClient{cc}
+}
+
+func (c *reasoningServiceClient) ApplyRules(ctx context.Context, in *ReasoningRequest, opts ...grpc.CallOption) (*ReasoningResponse, error) {
+	out := new(ReasoningResponse)
+	if err := c.cc.Invoke(ctx, ReasoningService_ApplyRules_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reasoningServiceClient) LoadRules(ctx context.Context, in *LoadRulesRequest, opts ...grpc.CallOption) (*LoadRulesResponse, error) {
+	out := new(LoadRulesResponse)
+	if err := c.cc.Invoke(ctx, ReasoningService_LoadRules_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reasoningServiceClient) HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
+	out := new(HealthCheckResponse)
+	if err := c.cc.Invoke(ctx, ReasoningService_HealthCheck_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reasoningServiceClient) ExplainConclusion(ctx context.Context, in *ExplainConclusionRequest, opts ...grpc.CallOption) (*ExplainConclusionResponse, error) {
+	out := new(ExplainConclusionResponse)
+	if err := c.cc.Invoke(ctx, ReasoningService_ExplainConclusion_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reasoningServiceClient) StreamApplyRules(ctx context.Context, opts ...grpc.CallOption) (ReasoningService_StreamApplyRulesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ReasoningService_ServiceDesc.Streams[0], ReasoningService_StreamApplyRules_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &reasoningServiceStreamApplyRulesClient{stream}, nil
+}
+
+// ReasoningService_StreamApplyRulesClient is the client-side stream
+// handle for the bidi-streaming StreamApplyRules RPC.
+type ReasoningService_StreamApplyRulesClient interface {
+	Send(*ReasoningRequest) error
+	Recv() (*ReasoningResponse, error)
+	grpc.ClientStream
+}
+
+type reasoningServiceStreamApplyRulesClient struct {
+	grpc.ClientStream
+}
+
+func (x *reasoningServiceStreamApplyRulesClient) Send(m *ReasoningRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *reasoningServiceStreamApplyRulesClient) Recv() (*ReasoningResponse, error) {
+	m := new(ReasoningResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *reasoningServiceClient) SubscribeConclusions(ctx context.Context, in *SubscribeConclusionsRequest, opts ...grpc.CallOption) (ReasoningService_SubscribeConclusionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ReasoningService_ServiceDesc.Streams[1], ReasoningService_SubscribeConclusions_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &reasoningServiceSubscribeConclusionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// ReasoningService_SubscribeConclusionsClient is the client-side stream
+// handle for the server-streaming SubscribeConclusions RPC.
+type ReasoningService_SubscribeConclusionsClient interface {
+	Recv() (*ConclusionEvent, error)
+	grpc.ClientStream
+}
+
+type reasoningServiceSubscribeConclusionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *reasoningServiceSubscribeConclusionsClient) Recv() (*ConclusionEvent, error) {
+	m := new(ConclusionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ReasoningServiceServer is the server API for ReasoningService.
+type ReasoningServiceServer interface {
+	ApplyRules(context.Context, *ReasoningRequest) (*ReasoningResponse, error)
+	LoadRules(context.Context, *LoadRulesRequest) (*LoadRulesResponse, error)
+	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
+	ExplainConclusion(context.Context, *ExplainConclusionRequest) (*ExplainConclusionResponse, error)
+	StreamApplyRules(ReasoningService_StreamApplyRulesServer) error
+	SubscribeConclusions(*SubscribeConclusionsRequest, ReasoningService_SubscribeConclusionsServer) error
+	mustEmbedUnimplementedReasoningServiceServer()
+}
+
+// UnimplementedReasoningServiceServer must be embedded by every
+// ReasoningServiceServer implementation for forward compatibility: a
+// server that doesn't implement a newly added method still satisfies the
+// interface and returns Unimplemented for it, rather than failing to
+// compile.
+type UnimplementedReasoningServiceServer struct{}
+
+func (UnimplementedReasoningServiceServer) ApplyRules(context.Context, *ReasoningRequest) (*ReasoningResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyRules not implemented")
+}
+
+func (UnimplementedReasoningServiceServer) LoadRules(context.Context, *LoadRulesRequest) (*LoadRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadRules not implemented")
+}
+
+func (UnimplementedReasoningServiceServer) HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
+}
+
+func (UnimplementedReasoningServiceServer) ExplainConclusion(context.Context, *ExplainConclusionRequest) (*ExplainConclusionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainConclusion not implemented")
+}
+
+func (UnimplementedReasoningServiceServer) StreamApplyRules(ReasoningService_StreamApplyRulesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamApplyRules not implemented")
+}
+
+func (UnimplementedReasoningServiceServer) SubscribeConclusions(*SubscribeConclusionsRequest, ReasoningService_SubscribeConclusionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeConclusions not implemented")
+}
+
+func (UnimplementedReasoningServiceServer) mustEmbedUnimplementedReasoningServiceServer() {}
+
+// UnsafeReasoningServiceServer may be embedded to opt out of forward
+// compatibility for this service; use of this interface is discouraged.
+type UnsafeReasoningServiceServer interface {
+	mustEmbedUnimplementedReasoningServiceServer()
+}
+
+func RegisterReasoningServiceServer(s grpc.ServiceRegistrar, srv ReasoningServiceServer) {
+	s.RegisterService(&ReasoningService_ServiceDesc, srv)
+}
+
+func _ReasoningService_ApplyRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReasoningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReasoningServiceServer).ApplyRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: ReasoningService_ApplyRules_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReasoningServiceServer).ApplyRules(ctx, req.(*ReasoningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReasoningService_LoadRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReasoningServiceServer).LoadRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: ReasoningService_LoadRules_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReasoningServiceServer).LoadRules(ctx, req.(*LoadRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReasoningService_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReasoningServiceServer).HealthCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: ReasoningService_HealthCheck_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReasoningServiceServer).HealthCheck(ctx, req.(*HealthCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReasoningService_ExplainConclusion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainConclusionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReasoningServiceServer).ExplainConclusion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: ReasoningService_ExplainConclusion_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReasoningServiceServer).ExplainConclusion(ctx, req.(*ExplainConclusionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReasoningService_StreamApplyRules_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ReasoningServiceServer).StreamApplyRules(&reasoningServiceStreamApplyRulesServer{stream})
+}
+
+// ReasoningService_StreamApplyRulesServer is the server-side stream
+// handle for the bidi-streaming StreamApplyRules RPC.
+type ReasoningService_StreamApplyRulesServer interface {
+	Send(*ReasoningResponse) error
+	Recv() (*ReasoningRequest, error)
+	grpc.ServerStream
+}
+
+type reasoningServiceStreamApplyRulesServer struct {
+	grpc.ServerStream
+}
+
+func (x *reasoningServiceStreamApplyRulesServer) Send(m *ReasoningResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *reasoningServiceStreamApplyRulesServer) Recv() (*ReasoningRequest, error) {
+	m := new(ReasoningRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ReasoningService_SubscribeConclusions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeConclusionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReasoningServiceServer).SubscribeConclusions(m, &reasoningServiceSubscribeConclusionsServer{stream})
+}
+
+// ReasoningService_SubscribeConclusionsServer is the server-side stream
+// handle for the server-streaming SubscribeConclusions RPC.
+type ReasoningService_SubscribeConclusionsServer interface {
+	Send(*ConclusionEvent) error
+	grpc.ServerStream
+}
+
+type reasoningServiceSubscribeConclusionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *reasoningServiceSubscribeConclusionsServer) Send(m *ConclusionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ReasoningService_ServiceDesc is the grpc.ServiceDesc for
+// ReasoningService; used by RegisterReasoningServiceServer and directly
+// by grpc.Server.
+var ReasoningService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "reasoning.ReasoningService",
+	HandlerType: (*ReasoningServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "ApplyRules", Handler: _ReasoningService_ApplyRules_Handler},
+		{MethodName: "LoadRules", Handler: _ReasoningService_LoadRules_Handler},
+		{MethodName: "HealthCheck", Handler: _ReasoningService_HealthCheck_Handler},
+		{MethodName: "ExplainConclusion", Handler: _ReasoningService_ExplainConclusion_Handler},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamApplyRules",
+			Handler:       _ReasoningService_StreamApplyRules_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SubscribeConclusions",
+			Handler:       _ReasoningService_SubscribeConclusions_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/reasoning.proto",
+}
@@ -0,0 +1,10 @@
+package reasoning
+
+import _ "embed"
+
+// SwaggerJSON is the OpenAPI/Swagger spec emitted alongside the gateway
+// stubs by protoc-gen-openapiv2; main.go serves it at /swagger.json so
+// non-gRPC clients can discover the REST surface.
+//
+//go:embed reasoning.swagger.json
+var SwaggerJSON []byte
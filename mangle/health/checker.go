@@ -0,0 +1,48 @@
+// Package health implements a pluggable health-check subsystem for the
+// mangle reasoning server: independent Checkers for things like "is the
+// Datalog engine loaded" or "is evaluation latency within budget" each
+// report a gRPC serving status, and a Monitor polls them on an interval
+// and pushes the results into a grpc/health.Server so that a client can
+// Watch() an individual subsystem rather than only the server as a whole.
+package health
+
+import (
+	"context"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Status is the gRPC serving status a Checker reports.
+type Status = healthpb.HealthCheckResponse_ServingStatus
+
+const (
+	// StatusServing means the subsystem is healthy.
+	StatusServing = healthpb.HealthCheckResponse_SERVING
+	// StatusNotServing means the subsystem is unhealthy; Monitor will
+	// mark it NOT_SERVING on the underlying health.Server.
+	StatusNotServing = healthpb.HealthCheckResponse_NOT_SERVING
+)
+
+// Checker is a single subsystem a Monitor polls on an interval. Name is
+// used both as the gRPC health service name (what a client passes to
+// Watch) and as a label in log output.
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) (status Status, detail string, err error)
+}
+
+// CheckFunc adapts a plain function to a Checker, analogous to
+// http.HandlerFunc.
+type CheckFunc struct {
+	name string
+	fn   func(ctx context.Context) (Status, string, error)
+}
+
+// NewCheckFunc builds a Checker named name from fn.
+func NewCheckFunc(name string, fn func(ctx context.Context) (Status, string, error)) CheckFunc {
+	return CheckFunc{name: name, fn: fn}
+}
+
+func (c CheckFunc) Name() string { return c.name }
+
+func (c CheckFunc) Check(ctx context.Context) (Status, string, error) { return c.fn(ctx) }
@@ -0,0 +1,100 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FailureTracker counts errors observed by UnaryServerInterceptor within
+// a trailing window and exposes the count as a Checker, so a burst of
+// internal failures shows up as NOT_SERVING even though every individual
+// RPC still returns a well-formed gRPC status to its caller.
+type FailureTracker struct {
+	mu        sync.Mutex
+	window    time.Duration
+	threshold int
+	failures  []time.Time
+}
+
+// NewFailureTracker builds a tracker that reports NOT_SERVING once more
+// than threshold failures have been recorded within the trailing window.
+func NewFailureTracker(window time.Duration, threshold int) *FailureTracker {
+	return &FailureTracker{window: window, threshold: threshold}
+}
+
+// Record notes a single failure at the current time.
+func (f *FailureTracker) Record() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = append(f.failures, time.Now())
+}
+
+// recentCount also compacts f.failures down to entries still inside the
+// window, so the slice doesn't grow unbounded under sustained traffic.
+func (f *FailureTracker) recentCount(now time.Time) int {
+	cutoff := now.Add(-f.window)
+	kept := f.failures[:0]
+	for _, t := range f.failures {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	f.failures = kept
+	return len(kept)
+}
+
+func (f *FailureTracker) Name() string { return "recent-failures" }
+
+func (f *FailureTracker) Check(ctx context.Context) (Status, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := f.recentCount(time.Now())
+	detail := fmt.Sprintf("%d failure(s) in the last %s (threshold %d)", n, f.window, f.threshold)
+	if n > f.threshold {
+		return StatusNotServing, detail, nil
+	}
+	return StatusServing, detail, nil
+}
+
+// UnaryServerInterceptor recovers panics and translates both panics and
+// non-gRPC errors into structured gRPC status codes, recording a failure
+// on tracker for each one so FailureTracker.Check reflects it. Ordinary
+// client-caused statuses (InvalidArgument, NotFound, FailedPrecondition,
+// and so on) pass through untouched and are not recorded as failures.
+func UnaryServerInterceptor(tracker *FailureTracker) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				tracker.Record()
+				log.Printf("health: recovered panic in %s: %v", info.FullMethod, r)
+				err = status.Errorf(codes.Internal, "internal error handling %s", info.FullMethod)
+			}
+		}()
+
+		resp, err = handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		// Only count failures the server itself is responsible for.
+		// Ordinary client-caused statuses (InvalidArgument, NotFound,
+		// FailedPrecondition, ...) are expected traffic, not evidence the
+		// server is unhealthy — counting them would let a burst of
+		// malformed requests trip recent-failures to NOT_SERVING.
+		switch status.Code(err) {
+		case codes.Unknown:
+			tracker.Record()
+			return resp, status.Errorf(codes.Internal, "%v", err)
+		case codes.Internal:
+			tracker.Record()
+		}
+		return resp, err
+	}
+}
@@ -0,0 +1,105 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFailureTrackerThreshold(t *testing.T) {
+	tracker := NewFailureTracker(time.Minute, 2)
+
+	status, _, err := tracker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if status != StatusServing {
+		t.Fatalf("expected StatusServing with no failures, got %v", status)
+	}
+
+	tracker.Record()
+	tracker.Record()
+	status, _, _ = tracker.Check(context.Background())
+	if status != StatusServing {
+		t.Fatalf("expected StatusServing at exactly the threshold, got %v", status)
+	}
+
+	tracker.Record()
+	status, detail, _ := tracker.Check(context.Background())
+	if status != StatusNotServing {
+		t.Fatalf("expected StatusNotServing once threshold is exceeded, got %v (%s)", status, detail)
+	}
+}
+
+func TestFailureTrackerWindowExpiry(t *testing.T) {
+	tracker := NewFailureTracker(10*time.Millisecond, 0)
+	tracker.Record()
+
+	status, _, _ := tracker.Check(context.Background())
+	if status != StatusNotServing {
+		t.Fatalf("expected StatusNotServing immediately after a failure with threshold 0, got %v", status)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	status, _, _ = tracker.Check(context.Background())
+	if status != StatusServing {
+		t.Fatalf("expected the failure to have aged out of the window, got %v", status)
+	}
+}
+
+func callInterceptor(t *testing.T, tracker *FailureTracker, handler grpc.UnaryHandler) error {
+	t.Helper()
+	intercept := UnaryServerInterceptor(tracker)
+	_, err := intercept(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}, handler)
+	return err
+}
+
+func TestUnaryServerInterceptorIgnoresClientErrors(t *testing.T) {
+	tracker := NewFailureTracker(time.Minute, 0)
+
+	clientCodes := []codes.Code{codes.InvalidArgument, codes.NotFound, codes.FailedPrecondition}
+	for _, code := range clientCodes {
+		code := code
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Errorf(code, "bad request")
+		}
+		if err := callInterceptor(t, tracker, handler); status.Code(err) != code {
+			t.Fatalf("expected %v to pass through unchanged, got %v", code, err)
+		}
+	}
+
+	if n := len(tracker.failures); n != 0 {
+		t.Fatalf("expected client-caused statuses not to be recorded as failures, got %d recorded", n)
+	}
+}
+
+func TestUnaryServerInterceptorRecordsInternalAndUnknownAndPanics(t *testing.T) {
+	tracker := NewFailureTracker(time.Minute, 0)
+
+	if err := callInterceptor(t, tracker, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Errorf(codes.Internal, "boom")
+	}); status.Code(err) != codes.Internal {
+		t.Fatalf("expected codes.Internal to pass through, got %v", err)
+	}
+
+	if err := callInterceptor(t, tracker, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("not a status error")
+	}); status.Code(err) != codes.Internal {
+		t.Fatalf("expected a non-status error to be translated to codes.Internal, got %v", err)
+	}
+
+	if err := callInterceptor(t, tracker, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("kaboom")
+	}); status.Code(err) != codes.Internal {
+		t.Fatalf("expected a recovered panic to be translated to codes.Internal, got %v", err)
+	}
+
+	if n := len(tracker.failures); n != 3 {
+		t.Fatalf("expected 3 recorded failures (internal, unknown, panic), got %d", n)
+	}
+}
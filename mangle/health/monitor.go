@@ -0,0 +1,61 @@
+package health
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ServingStatusSetter is the subset of grpc/health.Server that Monitor
+// needs; satisfied by *health.Server from google.golang.org/grpc/health.
+type ServingStatusSetter interface {
+	SetServingStatus(service string, status Status)
+}
+
+// Monitor periodically runs a set of Checkers and records their results
+// on a ServingStatusSetter, keyed by each Checker's Name.
+type Monitor struct {
+	server   ServingStatusSetter
+	interval time.Duration
+	checkers []Checker
+}
+
+// NewMonitor builds a Monitor that evaluates checkers every interval.
+func NewMonitor(server ServingStatusSetter, interval time.Duration, checkers ...Checker) *Monitor {
+	return &Monitor{server: server, interval: interval, checkers: checkers}
+}
+
+// Register adds another Checker to the monitor. Not safe to call once
+// Run has started.
+func (m *Monitor) Register(c Checker) {
+	m.checkers = append(m.checkers, c)
+}
+
+// Run evaluates every registered Checker immediately, then again every
+// interval, until ctx is cancelled.
+func (m *Monitor) Run(ctx context.Context) {
+	m.runOnce(ctx)
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.runOnce(ctx)
+		}
+	}
+}
+
+func (m *Monitor) runOnce(ctx context.Context) {
+	for _, c := range m.checkers {
+		status, detail, err := c.Check(ctx)
+		if err != nil {
+			status = StatusNotServing
+			log.Printf("health: %s check failed: %v", c.Name(), err)
+		} else if status == StatusNotServing {
+			log.Printf("health: %s is NOT_SERVING: %s", c.Name(), detail)
+		}
+		m.server.SetServingStatus(c.Name(), status)
+	}
+}